@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ErrHeaderNotFound is returned when the node has pruned or never had the
+// header for the requested block, mirroring the "header not found" error
+// go-ethereum's own RPC methods surface for getBalance/getCode/getStorageAt.
+var ErrHeaderNotFound = errors.New("header not found")
+
+// parseBlockParam turns a `?block=` query value into the *big.Int that
+// HeaderByNumber/BalanceAt expect. It accepts the well-known tags
+// ("latest", "earliest", "pending", "safe", "finalized"), decimal block
+// numbers, and 0x-prefixed hex block numbers. An empty value means "latest".
+func parseBlockParam(block string) (*big.Int, error) {
+	switch block {
+	case "", "latest":
+		return nil, nil
+	case "earliest":
+		return big.NewInt(int64(rpc.EarliestBlockNumber)), nil
+	case "pending":
+		return big.NewInt(int64(rpc.PendingBlockNumber)), nil
+	case "safe":
+		return big.NewInt(int64(rpc.SafeBlockNumber)), nil
+	case "finalized":
+		return big.NewInt(int64(rpc.FinalizedBlockNumber)), nil
+	}
+
+	if strings.HasPrefix(block, "0x") || strings.HasPrefix(block, "0X") {
+		number, ok := new(big.Int).SetString(block[2:], 16)
+		if !ok {
+			return nil, fmt.Errorf("invalid block %q", block)
+		}
+		return number, nil
+	}
+
+	number, ok := new(big.Int).SetString(block, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid block %q", block)
+	}
+	return number, nil
+}
+
+// isHeaderNotFoundErr reports whether err indicates the requested block's
+// header doesn't exist on the node, whether surfaced as the ethereum.NotFound
+// sentinel or as the raw "header not found" RPC error text some providers
+// (including Infura) return for eth_getBalance against a missing block.
+func isHeaderNotFoundErr(err error) bool {
+	return errors.Is(err, ethereum.NotFound) || strings.Contains(err.Error(), "header not found")
+}
+
+func (ec *EthereumClient) getBalanceAt(address string, blockNumber *big.Int) (*big.Int, error) {
+	account := common.HexToAddress(address)
+	balance, err := ec.client.BalanceAt(context.Background(), account, blockNumber)
+	if err != nil {
+		if isHeaderNotFoundErr(err) {
+			return nil, ErrHeaderNotFound
+		}
+		return nil, err
+	}
+	return balance, nil
+}
+
+func (ec *EthereumClient) getHeaderByNumber(blockNumber *big.Int) (*types.Header, error) {
+	header, err := ec.client.HeaderByNumber(context.Background(), blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return header, nil
+}
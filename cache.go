@@ -0,0 +1,120 @@
+package main
+
+import (
+	"container/list"
+	"fmt"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// cacheTTL bounds how long a cached HeaderByNumber(nil)/BalanceAt result is
+// reused before it's considered stale. Short enough that a historical
+// balance lookup can't go noticeably wrong, long enough to absorb a burst of
+// near-simultaneous dashboard refreshes into a single upstream call.
+// Overridable via ETH_CACHE_TTL (e.g. "500ms", "5s").
+var cacheTTL = envDuration("ETH_CACHE_TTL", 2*time.Second)
+
+func envDuration(key string, fallback time.Duration) time.Duration {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return fallback
+	}
+	return d
+}
+
+// cacheCapacity bounds how many distinct keys ttlCache holds at once. Without
+// this, an endpoint keyed by user-supplied values (address, block number)
+// would let a client grow the cache without bound just by querying enough
+// distinct addresses/blocks, even though each entry expires quickly.
+const cacheCapacity = 1024
+
+type cacheEntry struct {
+	key     string
+	value   interface{}
+	err     error
+	expires time.Time
+}
+
+// ttlCache is a capacity-bounded LRU cache where entries also expire after a
+// fixed TTL. Capacity bounds memory use independent of the key space (e.g.
+// the number of distinct addresses/blocks a client queries); TTL bounds how
+// long a stale result can be served.
+type ttlCache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*list.Element // key -> element in order, front = most recently used
+	order    *list.List               // of *cacheEntry
+}
+
+func newTTLCache() *ttlCache {
+	return &ttlCache{
+		capacity: cacheCapacity,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+func (c *ttlCache) get(key string) (value interface{}, err error, hit bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, nil, false
+	}
+	entry := elem.Value.(*cacheEntry)
+	if time.Now().After(entry.expires) {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+		return nil, nil, false
+	}
+	c.order.MoveToFront(elem)
+	return entry.value, entry.err, true
+}
+
+func (c *ttlCache) set(key string, value interface{}, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*cacheEntry)
+		entry.value, entry.err = value, err
+		entry.expires = time.Now().Add(cacheTTL)
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	for c.order.Len() >= c.capacity {
+		oldest := c.order.Back()
+		if oldest == nil {
+			break
+		}
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+
+	entry := &cacheEntry{key: key, value: value, err: err, expires: time.Now().Add(cacheTTL)}
+	c.entries[key] = c.order.PushFront(entry)
+}
+
+func headerCacheKey(blockNumber *big.Int) string {
+	return "header:" + blockNumberCacheKey(blockNumber)
+}
+
+func balanceCacheKey(account common.Address, blockNumber *big.Int) string {
+	return fmt.Sprintf("balance:%s:%s", account.Hex(), blockNumberCacheKey(blockNumber))
+}
+
+func blockNumberCacheKey(blockNumber *big.Int) string {
+	if blockNumber == nil {
+		return "latest"
+	}
+	return blockNumber.String()
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTTLCache_GetSetRoundTrip(t *testing.T) {
+	c := newTTLCache()
+	c.set("k", 42, nil)
+
+	value, err, hit := c.get("k")
+	assert.True(t, hit)
+	assert.NoError(t, err)
+	assert.Equal(t, 42, value)
+}
+
+func TestTTLCache_ExpiresAfterTTL(t *testing.T) {
+	c := newTTLCache()
+	old := cacheTTL
+	cacheTTL = time.Millisecond
+	defer func() { cacheTTL = old }()
+
+	c.set("k", 42, nil)
+	time.Sleep(5 * time.Millisecond)
+
+	_, _, hit := c.get("k")
+	assert.False(t, hit)
+}
+
+func TestTTLCache_EvictsLeastRecentlyUsedAtCapacity(t *testing.T) {
+	c := newTTLCache()
+	c.capacity = 2
+
+	c.set("a", 1, nil)
+	c.set("b", 2, nil)
+	// Touch "a" so "b" becomes the least recently used entry.
+	_, _, _ = c.get("a")
+	c.set("c", 3, nil)
+
+	_, _, hitA := c.get("a")
+	_, _, hitB := c.get("b")
+	_, _, hitC := c.get("c")
+
+	assert.True(t, hitA)
+	assert.False(t, hitB, "least recently used entry should have been evicted")
+	assert.True(t, hitC)
+	assert.Len(t, c.entries, 2)
+}
+
+func TestTTLCache_CapacityBoundsUnboundedKeySpace(t *testing.T) {
+	c := newTTLCache()
+	c.capacity = 10
+
+	for i := 0; i < 1000; i++ {
+		c.set(fmt.Sprintf("balance:0x%d:latest", i), i, nil)
+	}
+
+	assert.LessOrEqual(t, len(c.entries), 10)
+}
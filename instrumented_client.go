@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// instrumentedClient wraps an EthereumClientInterface to record Prometheus
+// metrics for every upstream call and to serve HeaderByNumber(nil) and
+// BalanceAt from a short-TTL cache, so a burst of dashboard refreshes
+// doesn't turn into a burst of upstream RPC calls.
+type instrumentedClient struct {
+	underlying EthereumClientInterface
+	cache      *ttlCache
+}
+
+func newInstrumentedClient(underlying EthereumClientInterface) *instrumentedClient {
+	return &instrumentedClient{underlying: underlying, cache: newTTLCache()}
+}
+
+func (ic *instrumentedClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if number == nil {
+		key := headerCacheKey(number)
+		if v, err, ok := ic.cache.get(key); ok {
+			cacheHits.WithLabelValues("HeaderByNumber").Inc()
+			header, _ := v.(*types.Header)
+			return header, err
+		}
+		cacheMisses.WithLabelValues("HeaderByNumber").Inc()
+
+		start := time.Now()
+		header, err := ic.underlying.HeaderByNumber(ctx, number)
+		recordRPCDuration("HeaderByNumber", start, err)
+		if err == nil {
+			ic.cache.set(key, header, nil)
+		}
+		return header, err
+	}
+
+	start := time.Now()
+	header, err := ic.underlying.HeaderByNumber(ctx, number)
+	recordRPCDuration("HeaderByNumber", start, err)
+	return header, err
+}
+
+func (ic *instrumentedClient) BalanceAt(ctx context.Context, account common.Address, number *big.Int) (*big.Int, error) {
+	key := balanceCacheKey(account, number)
+	if v, err, ok := ic.cache.get(key); ok {
+		cacheHits.WithLabelValues("BalanceAt").Inc()
+		balance, _ := v.(*big.Int)
+		return balance, err
+	}
+	cacheMisses.WithLabelValues("BalanceAt").Inc()
+
+	start := time.Now()
+	balance, err := ic.underlying.BalanceAt(ctx, account, number)
+	recordRPCDuration("BalanceAt", start, err)
+	// The "latest" balance (number == nil) changes every block, so a
+	// transient upstream error there must not be replayed to every caller
+	// for the rest of the TTL once the upstream has already recovered.
+	// Historical lookups are pinned to a fixed block and are deterministic,
+	// so caching their errors too is harmless.
+	if err == nil || number != nil {
+		ic.cache.set(key, balance, err)
+	}
+	return balance, err
+}
+
+func (ic *instrumentedClient) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	start := time.Now()
+	sub, err := ic.underlying.SubscribeNewHead(ctx, ch)
+	recordRPCDuration("SubscribeNewHead", start, err)
+	return sub, err
+}
+
+func (ic *instrumentedClient) SubscribePendingTransactions(ctx context.Context, ch chan<- common.Hash) (ethereum.Subscription, error) {
+	start := time.Now()
+	sub, err := ic.underlying.SubscribePendingTransactions(ctx, ch)
+	recordRPCDuration("SubscribePendingTransactions", start, err)
+	return sub, err
+}
+
+func (ic *instrumentedClient) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	start := time.Now()
+	result, err := ic.underlying.CallContract(ctx, msg, blockNumber)
+	recordRPCDuration("CallContract", start, err)
+	return result, err
+}
+
+func (ic *instrumentedClient) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	start := time.Now()
+	logs, err := ic.underlying.FilterLogs(ctx, query)
+	recordRPCDuration("FilterLogs", start, err)
+	return logs, err
+}
+
+func (ic *instrumentedClient) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	start := time.Now()
+	sub, err := ic.underlying.SubscribeFilterLogs(ctx, query, ch)
+	recordRPCDuration("SubscribeFilterLogs", start, err)
+	return sub, err
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestInstrumentedClient_CachesLatestHeader(t *testing.T) {
+	mockClient := new(MockEthereumClient)
+	expectedHeader := &types.Header{Number: big.NewInt(100)}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(expectedHeader, nil).Once()
+
+	ic := newInstrumentedClient(mockClient)
+	ethClient := NewEthereumClient(ic)
+
+	for i := 0; i < 5; i++ {
+		blockNumber, err := ethClient.getLatestBlockNumber()
+		assert.NoError(t, err)
+		assert.Equal(t, expectedHeader.Number, blockNumber)
+	}
+
+	// Only the first call should have reached the underlying client; the
+	// rest were served from the cache, so .Once() above isn't violated.
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNumberOfCalls(t, "HeaderByNumber", 1)
+}
+
+func TestInstrumentedClient_CachesBalanceByAddressAndBlock(t *testing.T) {
+	mockClient := new(MockEthereumClient)
+	address := common.HexToAddress("0x1")
+	blockNumber := big.NewInt(42)
+	expectedBalance := big.NewInt(500)
+	mockClient.On("BalanceAt", mock.Anything, address, blockNumber).Return(expectedBalance, nil).Once()
+
+	ic := newInstrumentedClient(mockClient)
+	ethClient := NewEthereumClient(ic)
+
+	for i := 0; i < 3; i++ {
+		balance, err := ethClient.getBalanceAt(address.Hex(), blockNumber)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedBalance, balance)
+	}
+
+	mockClient.AssertNumberOfCalls(t, "BalanceAt", 1)
+}
+
+func TestInstrumentedClient_DoesNotCacheHistoricalHeaders(t *testing.T) {
+	mockClient := new(MockEthereumClient)
+	blockNumber := big.NewInt(100)
+	header := &types.Header{Number: blockNumber}
+	mockClient.On("HeaderByNumber", mock.Anything, blockNumber).Return(header, nil).Twice()
+
+	ic := newInstrumentedClient(mockClient)
+	ethClient := NewEthereumClient(ic)
+
+	_, err := ethClient.getHeaderByNumber(blockNumber)
+	assert.NoError(t, err)
+	_, err = ethClient.getHeaderByNumber(blockNumber)
+	assert.NoError(t, err)
+
+	mockClient.AssertNumberOfCalls(t, "HeaderByNumber", 2)
+}
+
+func TestInstrumentedClient_DoesNotCacheLatestHeaderError(t *testing.T) {
+	mockClient := new(MockEthereumClient)
+	wantErr := errors.New("connection reset")
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return((*types.Header)(nil), wantErr).Twice()
+
+	ic := newInstrumentedClient(mockClient)
+	ethClient := NewEthereumClient(ic)
+
+	_, err := ethClient.getLatestBlockNumber()
+	assert.ErrorIs(t, err, wantErr)
+	_, err = ethClient.getLatestBlockNumber()
+	assert.ErrorIs(t, err, wantErr)
+
+	// Both calls should have reached the underlying client: a transient
+	// error on the "latest" header must not be cached and replayed.
+	mockClient.AssertNumberOfCalls(t, "HeaderByNumber", 2)
+}
+
+func TestInstrumentedClient_DoesNotCacheLiveBalanceError(t *testing.T) {
+	mockClient := new(MockEthereumClient)
+	address := common.HexToAddress("0x1")
+	wantErr := errors.New("connection reset")
+	mockClient.On("BalanceAt", mock.Anything, address, (*big.Int)(nil)).Return((*big.Int)(nil), wantErr).Twice()
+
+	ic := newInstrumentedClient(mockClient)
+	ethClient := NewEthereumClient(ic)
+
+	_, err := ethClient.getBalance(address.Hex())
+	assert.ErrorIs(t, err, wantErr)
+	_, err = ethClient.getBalance(address.Hex())
+	assert.ErrorIs(t, err, wantErr)
+
+	mockClient.AssertNumberOfCalls(t, "BalanceAt", 2)
+}
+
+func TestInstrumentedClient_CachesHistoricalBalanceError(t *testing.T) {
+	mockClient := new(MockEthereumClient)
+	address := common.HexToAddress("0x1")
+	blockNumber := big.NewInt(42)
+	wantErr := errors.New("rpc timeout")
+	mockClient.On("BalanceAt", mock.Anything, address, blockNumber).Return((*big.Int)(nil), wantErr).Once()
+
+	ic := newInstrumentedClient(mockClient)
+	ethClient := NewEthereumClient(ic)
+
+	for i := 0; i < 3; i++ {
+		_, err := ethClient.getBalanceAt(address.Hex(), blockNumber)
+		assert.ErrorIs(t, err, wantErr)
+	}
+
+	// Historical lookups are deterministic, so the error is cached just like
+	// a successful result would be.
+	mockClient.AssertNumberOfCalls(t, "BalanceAt", 1)
+}
+
+func TestInstrumentedClient_DifferentAddressesAreNotCachedTogether(t *testing.T) {
+	mockClient := new(MockEthereumClient)
+	addr1 := common.HexToAddress("0x1")
+	addr2 := common.HexToAddress("0x2")
+	mockClient.On("BalanceAt", mock.Anything, addr1, (*big.Int)(nil)).Return(big.NewInt(1), nil).Once()
+	mockClient.On("BalanceAt", mock.Anything, addr2, (*big.Int)(nil)).Return(big.NewInt(2), nil).Once()
+
+	ic := newInstrumentedClient(mockClient)
+	ethClient := NewEthereumClient(ic)
+
+	b1, err := ethClient.getBalance(addr1.Hex())
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(1), b1)
+
+	b2, err := ethClient.getBalance(addr2.Hex())
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(2), b2)
+
+	mockClient.AssertExpectations(t)
+}
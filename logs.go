@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// transferEventTopic is keccak256("Transfer(address,address,uint256)"), the
+// ERC-20 Transfer event signature.
+var transferEventTopic = common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+
+// transferEvent is the decoded form of an ERC-20 Transfer log.
+type transferEvent struct {
+	From  common.Address `json:"from"`
+	To    common.Address `json:"to"`
+	Value *big.Int       `json:"value"`
+}
+
+// decodeTransferEvent decodes log as an ERC-20 Transfer event, reporting
+// false if it isn't shaped like one.
+func decodeTransferEvent(entry types.Log) (*transferEvent, bool) {
+	if len(entry.Topics) != 3 || entry.Topics[0] != transferEventTopic || len(entry.Data) != 32 {
+		return nil, false
+	}
+	return &transferEvent{
+		From:  common.BytesToAddress(entry.Topics[1].Bytes()),
+		To:    common.BytesToAddress(entry.Topics[2].Bytes()),
+		Value: new(big.Int).SetBytes(entry.Data),
+	}, true
+}
+
+// logJSON marshals a log the same way the node's JSON-RPC does, but adds a
+// "decoded" field for logs we recognize (currently just ERC-20 Transfer).
+func logJSON(entry types.Log) ([]byte, error) {
+	raw, err := json.Marshal(entry)
+	if err != nil {
+		return nil, err
+	}
+	decoded, ok := decodeTransferEvent(entry)
+	if !ok {
+		return raw, nil
+	}
+	var withDecoded map[string]interface{}
+	if err := json.Unmarshal(raw, &withDecoded); err != nil {
+		return nil, err
+	}
+	withDecoded["decoded"] = decoded
+	return json.Marshal(withDecoded)
+}
+
+// parseFilterQuery builds an ethereum.FilterQuery from the query params of
+// an HTTP request: repeated `address`, `fromBlock`/`toBlock` (same tags/
+// formats as the `block` param elsewhere), and repeated `topic0`..`topic3`
+// for OR-matching at each topic position.
+func parseFilterQuery(values url.Values) (ethereum.FilterQuery, error) {
+	var query ethereum.FilterQuery
+
+	for _, addr := range values["address"] {
+		if !common.IsHexAddress(addr) {
+			return query, fmt.Errorf("invalid address %q", addr)
+		}
+		query.Addresses = append(query.Addresses, common.HexToAddress(addr))
+	}
+
+	fromBlock, err := parseBlockParam(values.Get("fromBlock"))
+	if err != nil {
+		return query, err
+	}
+	query.FromBlock = fromBlock
+
+	toBlock, err := parseBlockParam(values.Get("toBlock"))
+	if err != nil {
+		return query, err
+	}
+	query.ToBlock = toBlock
+
+	var topics [][]common.Hash
+	lastSet := -1
+	for i := 0; i < 4; i++ {
+		raw := values[fmt.Sprintf("topic%d", i)]
+		topics = append(topics, nil)
+		if len(raw) == 0 {
+			continue
+		}
+		hashes := make([]common.Hash, len(raw))
+		for j, h := range raw {
+			hashes[j] = common.HexToHash(h)
+		}
+		topics[i] = hashes
+		lastSet = i
+	}
+	if lastSet >= 0 {
+		query.Topics = topics[:lastSet+1]
+	}
+
+	return query, nil
+}
+
+func (ec *EthereumClient) getFilterLogs(query ethereum.FilterQuery) ([]types.Log, error) {
+	return ec.client.FilterLogs(context.Background(), query)
+}
+
+func (ec *EthereumClient) subscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return ec.client.SubscribeFilterLogs(ctx, query, ch)
+}
+
+func (r *ClientRegistry) FilterLogs(name string, query ethereum.FilterQuery) ([]types.Log, error) {
+	c, err := r.chainByName(name)
+	if err != nil {
+		return nil, err
+	}
+	var logs []types.Log
+	err = c.do(func(ec *EthereumClient) error {
+		l, err := ec.getFilterLogs(query)
+		if err != nil {
+			return err
+		}
+		logs = l
+		return nil
+	})
+	return logs, err
+}
+
+// logsHandler streams logs matching the request's filter as
+// newline-delimited JSON.
+func logsHandler(registry *ClientRegistry, chain string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query, err := parseFilterQuery(r.URL.Query())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		logs, err := registry.FilterLogs(chain, query)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		flusher, _ := w.(http.Flusher)
+		for _, entry := range logs {
+			payload, err := logJSON(entry)
+			if err != nil {
+				continue
+			}
+			w.Write(payload)
+			w.Write([]byte("\n"))
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+	}
+}
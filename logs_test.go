@@ -0,0 +1,116 @@
+package main
+
+import (
+	"math/big"
+	"net/url"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestParseFilterQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   url.Values
+		want    ethereum.FilterQuery
+		wantErr bool
+	}{
+		{
+			name:  "empty",
+			query: url.Values{},
+			want:  ethereum.FilterQuery{},
+		},
+		{
+			name: "address and block range",
+			query: url.Values{
+				"address":   {"0x0000000000000000000000000000000000000001"},
+				"fromBlock": {"100"},
+				"toBlock":   {"latest"},
+			},
+			want: ethereum.FilterQuery{
+				Addresses: []common.Address{common.HexToAddress("0x1")},
+				FromBlock: big.NewInt(100),
+				ToBlock:   nil,
+			},
+		},
+		{
+			name: "topics with gaps",
+			query: url.Values{
+				"topic0": {"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"},
+				"topic2": {"0x01", "0x02"},
+			},
+			want: ethereum.FilterQuery{
+				Topics: [][]common.Hash{
+					{common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")},
+					nil,
+					{common.HexToHash("0x01"), common.HexToHash("0x02")},
+				},
+			},
+		},
+		{
+			name:    "invalid address",
+			query:   url.Values{"address": {"not-an-address"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid block",
+			query:   url.Values{"fromBlock": {"not-a-block"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFilterQuery(tt.query)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDecodeTransferEvent(t *testing.T) {
+	from := common.HexToAddress("0x1")
+	to := common.HexToAddress("0x2")
+	value := big.NewInt(500)
+
+	transferLog := types.Log{
+		Topics: []common.Hash{
+			transferEventTopic,
+			common.BytesToHash(from.Bytes()),
+			common.BytesToHash(to.Bytes()),
+		},
+		Data: common.LeftPadBytes(value.Bytes(), 32),
+	}
+
+	decoded, ok := decodeTransferEvent(transferLog)
+	assert.True(t, ok)
+	assert.Equal(t, from, decoded.From)
+	assert.Equal(t, to, decoded.To)
+	assert.Equal(t, value, decoded.Value)
+
+	_, ok = decodeTransferEvent(types.Log{Topics: []common.Hash{transferEventTopic}})
+	assert.False(t, ok)
+}
+
+func TestGetFilterLogs(t *testing.T) {
+	query := ethereum.FilterQuery{Addresses: []common.Address{common.HexToAddress("0x1")}}
+	expectedLogs := []types.Log{{Address: common.HexToAddress("0x1")}}
+
+	mockClient := new(MockEthereumClient)
+	mockClient.On("FilterLogs", mock.Anything, query).Return(expectedLogs, nil)
+
+	ethClient := NewEthereumClient(mockClient)
+	logs, err := ethClient.getFilterLogs(query)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedLogs, logs)
+	mockClient.AssertExpectations(t)
+}
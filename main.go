@@ -3,22 +3,45 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"math/big"
 	"net/http"
+	"os"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
-const infuraURL = "https://mainnet.infura.io/v3/c543932173e54a3fbbe7ce8e4d0c1e78"
+// defaultChainsConfigPath is used when CHAINS_CONFIG_PATH isn't set.
+const defaultChainsConfigPath = "chains.yaml"
 
 // EthereumClientInterface defines the methods that our EthereumClient should implement
 type EthereumClientInterface interface {
 	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
 	BalanceAt(ctx context.Context, account common.Address, number *big.Int) (*big.Int, error)
+	SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error)
+	SubscribePendingTransactions(ctx context.Context, ch chan<- common.Hash) (ethereum.Subscription, error)
+	CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+	FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error)
+	SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error)
+}
+
+// ethClientAdapter adds the non-standard newPendingTransactions subscription
+// to *ethclient.Client, which only exposes the subscriptions go-ethereum
+// itself defines. Infura (and most providers) support it as a raw eth_subscribe
+// topic, so we issue it directly through the underlying rpc.Client.
+type ethClientAdapter struct {
+	*ethclient.Client
+	rpcClient *rpc.Client
+}
+
+func (ec *ethClientAdapter) SubscribePendingTransactions(ctx context.Context, ch chan<- common.Hash) (ethereum.Subscription, error) {
+	return ec.rpcClient.EthSubscribe(ctx, ch, "newPendingTransactions")
 }
 
 // EthereumClient wraps an ethclient.Client
@@ -39,45 +62,165 @@ func (ec *EthereumClient) getLatestBlockNumber() (*big.Int, error) {
 }
 
 func (ec *EthereumClient) getBalance(address string) (*big.Int, error) {
-	account := common.HexToAddress(address)
-	balance, err := ec.client.BalanceAt(context.Background(), account, nil)
-	if err != nil {
-		return nil, err
-	}
-	return balance, nil
+	return ec.getBalanceAt(address, nil)
+}
+
+// subscribeNewHeads subscribes to newly mined block headers.
+func (ec *EthereumClient) subscribeNewHeads(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	return ec.client.SubscribeNewHead(ctx, ch)
+}
+
+// subscribePendingTransactions subscribes to transaction hashes as they enter the mempool.
+func (ec *EthereumClient) subscribePendingTransactions(ctx context.Context, ch chan<- common.Hash) (ethereum.Subscription, error) {
+	return ec.client.SubscribePendingTransactions(ctx, ch)
 }
 
 func main() {
-	client, err := ethclient.Dial(infuraURL)
+	configPath := os.Getenv("CHAINS_CONFIG_PATH")
+	if configPath == "" {
+		configPath = defaultChainsConfigPath
+	}
+	chainConfigs, err := LoadChainConfigs(configPath)
+	if err != nil {
+		log.Fatalf("Failed to load chain config %s: %v", configPath, err)
+	}
+	if len(chainConfigs) == 0 {
+		log.Fatalf("No chains configured in %s", configPath)
+	}
+
+	registry, err := NewClientRegistry(chainConfigs)
 	if err != nil {
 		log.Fatalf("Failed to connect to Ethereum: %v", err)
 	}
 
-	ethClient := NewEthereumClient(client)
+	defaultChain := os.Getenv("DEFAULT_CHAIN")
+	if defaultChain == "" {
+		defaultChain = chainConfigs[0].Name
+	}
+
+	http.HandleFunc("/ws/new-heads", func(w http.ResponseWriter, r *http.Request) {
+		ethClient, err := registry.ClientFor(chainName(r, defaultChain))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		newHeadsWSHandler(ethClient)(w, r)
+	})
+	http.HandleFunc("/ws/pending-txs", func(w http.ResponseWriter, r *http.Request) {
+		ethClient, err := registry.ClientFor(chainName(r, defaultChain))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		pendingTxsWSHandler(ethClient)(w, r)
+	})
 
 	http.HandleFunc("/latest-block", func(w http.ResponseWriter, r *http.Request) {
-		blockNumber, err := ethClient.getLatestBlockNumber()
+		latestBlockHandler(registry, chainName(r, defaultChain))(w, r)
+	})
+	http.HandleFunc("/balance", func(w http.ResponseWriter, r *http.Request) {
+		balanceHandler(registry, chainName(r, defaultChain))(w, r)
+	})
+	http.HandleFunc("/header", func(w http.ResponseWriter, r *http.Request) {
+		headerHandler(registry, chainName(r, defaultChain))(w, r)
+	})
+
+	http.HandleFunc("/chains/{name}/latest-block", func(w http.ResponseWriter, r *http.Request) {
+		latestBlockHandler(registry, r.PathValue("name"))(w, r)
+	})
+	http.HandleFunc("/chains/{name}/balance", func(w http.ResponseWriter, r *http.Request) {
+		balanceHandler(registry, r.PathValue("name"))(w, r)
+	})
+
+	http.HandleFunc("/token-balance", func(w http.ResponseWriter, r *http.Request) {
+		tokenBalanceHandler(registry, chainName(r, defaultChain))(w, r)
+	})
+
+	http.HandleFunc("/logs", func(w http.ResponseWriter, r *http.Request) {
+		logsHandler(registry, chainName(r, defaultChain))(w, r)
+	})
+	http.HandleFunc("/ws/logs", func(w http.ResponseWriter, r *http.Request) {
+		query, err := parseFilterQuery(r.URL.Query())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		ethClient, err := registry.ClientFor(chainName(r, defaultChain))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		logsWSHandler(ethClient, query)(w, r)
+	})
+
+	http.Handle("/metrics", metricsHandler())
+
+	fmt.Println("Server running on port 8080")
+	log.Fatal(http.ListenAndServe(":8080", withRequestLogging(http.DefaultServeMux)))
+}
+
+// chainName resolves which chain a legacy (non /chains/{name}/...) route
+// should hit: the "chain" query param if set, otherwise the configured
+// default chain.
+func chainName(r *http.Request, defaultChain string) string {
+	if name := r.URL.Query().Get("chain"); name != "" {
+		return name
+	}
+	return defaultChain
+}
+
+func latestBlockHandler(registry *ClientRegistry, chain string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		blockNumber, err := registry.LatestBlockNumber(chain)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 		json.NewEncoder(w).Encode(map[string]interface{}{"latest_block": blockNumber.String()})
-	})
+	}
+}
 
-	http.HandleFunc("/balance", func(w http.ResponseWriter, r *http.Request) {
+func balanceHandler(registry *ClientRegistry, chain string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
 		address := r.URL.Query().Get("address")
 		if address == "" {
 			http.Error(w, "Address is required", http.StatusBadRequest)
 			return
 		}
-		balance, err := ethClient.getBalance(address)
+		blockNumber, err := parseBlockParam(r.URL.Query().Get("block"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		balance, err := registry.BalanceAt(chain, address, blockNumber)
 		if err != nil {
+			if errors.Is(err, ErrHeaderNotFound) {
+				http.Error(w, err.Error(), http.StatusNotFound)
+				return
+			}
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 		json.NewEncoder(w).Encode(map[string]interface{}{"balance": balance.String()})
-	})
+	}
+}
 
-	fmt.Println("Server running on port 8080")
-	log.Fatal(http.ListenAndServe(":8080", nil))
+func headerHandler(registry *ClientRegistry, chain string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		blockNumber, err := parseBlockParam(r.URL.Query().Get("block"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		header, err := registry.HeaderByNumber(chain, blockNumber)
+		if err != nil {
+			if errors.Is(err, ethereum.NotFound) {
+				http.Error(w, err.Error(), http.StatusNotFound)
+				return
+			}
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		json.NewEncoder(w).Encode(header)
+	}
 }
@@ -2,11 +2,14 @@ package main
 
 import (
 	"context"
+	"errors"
 	"math/big"
 	"testing"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -26,6 +29,36 @@ func (m *MockEthereumClient) BalanceAt(ctx context.Context, account common.Addre
 	return args.Get(0).(*big.Int), args.Error(1)
 }
 
+func (m *MockEthereumClient) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	args := m.Called(ctx, ch)
+	sub, _ := args.Get(0).(ethereum.Subscription)
+	return sub, args.Error(1)
+}
+
+func (m *MockEthereumClient) SubscribePendingTransactions(ctx context.Context, ch chan<- common.Hash) (ethereum.Subscription, error) {
+	args := m.Called(ctx, ch)
+	sub, _ := args.Get(0).(ethereum.Subscription)
+	return sub, args.Error(1)
+}
+
+func (m *MockEthereumClient) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	args := m.Called(ctx, msg, blockNumber)
+	result, _ := args.Get(0).([]byte)
+	return result, args.Error(1)
+}
+
+func (m *MockEthereumClient) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	args := m.Called(ctx, query)
+	logs, _ := args.Get(0).([]types.Log)
+	return logs, args.Error(1)
+}
+
+func (m *MockEthereumClient) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	args := m.Called(ctx, query, ch)
+	sub, _ := args.Get(0).(ethereum.Subscription)
+	return sub, args.Error(1)
+}
+
 func TestGetLatestBlockNumber(t *testing.T) {
 	mockClient := new(MockEthereumClient)
 	expectedBlockNumber := big.NewInt(12345)
@@ -52,3 +85,66 @@ func TestGetBalance(t *testing.T) {
 	assert.Equal(t, expectedBalance, balance)
 	mockClient.AssertExpectations(t)
 }
+
+func TestGetBalanceAt(t *testing.T) {
+	mockClient := new(MockEthereumClient)
+	expectedBalance := big.NewInt(1000)
+	address := common.HexToAddress("0x0")
+	blockNumber := big.NewInt(100)
+	mockClient.On("BalanceAt", mock.Anything, address, blockNumber).Return(expectedBalance, nil)
+
+	ethClient := NewEthereumClient(mockClient)
+	balance, err := ethClient.getBalanceAt(address.Hex(), blockNumber)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedBalance, balance)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGetBalanceAt_HeaderNotFound(t *testing.T) {
+	mockClient := new(MockEthereumClient)
+	address := common.HexToAddress("0x0")
+	blockNumber := big.NewInt(999999999)
+	mockClient.On("BalanceAt", mock.Anything, address, blockNumber).Return((*big.Int)(nil), errors.New("header not found"))
+
+	ethClient := NewEthereumClient(mockClient)
+	balance, err := ethClient.getBalanceAt(address.Hex(), blockNumber)
+
+	assert.Nil(t, balance)
+	assert.ErrorIs(t, err, ErrHeaderNotFound)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGetHeaderByNumber_NotFound(t *testing.T) {
+	mockClient := new(MockEthereumClient)
+	blockNumber := big.NewInt(999999999)
+	mockClient.On("HeaderByNumber", mock.Anything, blockNumber).Return((*types.Header)(nil), ethereum.NotFound)
+
+	ethClient := NewEthereumClient(mockClient)
+	header, err := ethClient.getHeaderByNumber(blockNumber)
+
+	assert.Nil(t, header)
+	assert.ErrorIs(t, err, ethereum.NotFound)
+	mockClient.AssertExpectations(t)
+}
+
+func TestParseBlockParam(t *testing.T) {
+	latest, err := parseBlockParam("latest")
+	assert.NoError(t, err)
+	assert.Nil(t, latest)
+
+	earliest, err := parseBlockParam("earliest")
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(int64(rpc.EarliestBlockNumber)), earliest)
+
+	decimal, err := parseBlockParam("100")
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(100), decimal)
+
+	hex, err := parseBlockParam("0x64")
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(100), hex)
+
+	_, err = parseBlockParam("not-a-block")
+	assert.Error(t, err)
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	rpcDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "eth_rpc_duration_seconds",
+		Help:    "Duration of upstream Ethereum RPC calls, by method and status.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "status"})
+
+	cacheHits = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "eth_rpc_cache_hits_total",
+		Help: "RPC calls served from the short-TTL cache, by method.",
+	}, []string{"method"})
+
+	cacheMisses = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "eth_rpc_cache_misses_total",
+		Help: "RPC calls that missed the short-TTL cache, by method.",
+	}, []string{"method"})
+)
+
+// recordRPCDuration observes how long an upstream RPC call took, labeled
+// with whether it returned an error.
+func recordRPCDuration(method string, start time.Time, err error) {
+	status := "ok"
+	if err != nil {
+		status = "error"
+	}
+	rpcDuration.WithLabelValues(method, status).Observe(time.Since(start).Seconds())
+}
+
+func metricsHandler() http.Handler {
+	return promhttp.Handler()
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"gopkg.in/yaml.v3"
+)
+
+// ChainConfig describes one named chain and its ordered list of fallback RPC
+// endpoints. The first reachable endpoint is preferred; later ones are only
+// used once earlier ones start failing.
+type ChainConfig struct {
+	Name    string   `yaml:"name" json:"name"`
+	RPCURLs []string `yaml:"rpc_urls" json:"rpc_urls"`
+}
+
+// endpointCooldown is how long a failing endpoint is skipped before it's
+// given another chance.
+const endpointCooldown = 30 * time.Second
+
+type chainEndpoint struct {
+	url            string
+	client         *EthereumClient
+	unhealthyUntil time.Time
+}
+
+// chain is the failover-capable endpoint list backing one named chain.
+type chain struct {
+	mu        sync.Mutex
+	endpoints []*chainEndpoint
+}
+
+// do runs fn against the first healthy endpoint, falling back to the next
+// one on error and marking the failing endpoint unhealthy for
+// endpointCooldown. Errors that mean "the call succeeded, the data just
+// doesn't exist" (ErrHeaderNotFound / ethereum.NotFound) are not failures of
+// the endpoint and are returned immediately without trying another one.
+func (c *chain) do(fn func(*EthereumClient) error) error {
+	c.mu.Lock()
+	endpoints := append([]*chainEndpoint(nil), c.endpoints...)
+	c.mu.Unlock()
+
+	var lastErr error
+	for _, ep := range endpoints {
+		c.mu.Lock()
+		unhealthy := ep.unhealthyUntil.After(time.Now())
+		c.mu.Unlock()
+		if unhealthy {
+			continue
+		}
+
+		err := fn(ep.client)
+		if err == nil {
+			return nil
+		}
+		if errors.Is(err, ErrHeaderNotFound) || errors.Is(err, ethereum.NotFound) {
+			return err
+		}
+
+		lastErr = err
+		c.mu.Lock()
+		ep.unhealthyUntil = time.Now().Add(endpointCooldown)
+		c.mu.Unlock()
+	}
+	if lastErr == nil {
+		lastErr = errors.New("no healthy RPC endpoints configured")
+	}
+	return fmt.Errorf("all endpoints exhausted: %w", lastErr)
+}
+
+// ClientRegistry dials every configured chain's fallback endpoints up front
+// and routes calls to the first healthy one. It replaces the old singleton
+// EthereumClient tied to a single hard-coded Infura URL.
+type ClientRegistry struct {
+	chains map[string]*chain
+}
+
+// LoadChainConfigs reads a YAML (or JSON, which is a YAML subset) file of
+// ChainConfig entries from path.
+func LoadChainConfigs(path string) ([]ChainConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var configs []ChainConfig
+	if err := yaml.Unmarshal(data, &configs); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return configs, nil
+}
+
+// resolveRPCURL expands ${VAR} references in url against the environment, so
+// a default config can ship a placeholder instead of a live-looking
+// credential. A URL that is entirely a single unset/empty variable resolves
+// to "" and is dropped by the caller, rather than being dialed literally or
+// as an empty string.
+func resolveRPCURL(url string) string {
+	return os.Expand(url, os.Getenv)
+}
+
+// NewClientRegistry dials every RPC URL in configs and returns a registry
+// ready to route requests by chain name.
+func NewClientRegistry(configs []ChainConfig) (*ClientRegistry, error) {
+	registry := &ClientRegistry{chains: make(map[string]*chain, len(configs))}
+	for _, cfg := range configs {
+		if len(cfg.RPCURLs) == 0 {
+			return nil, fmt.Errorf("chain %q has no rpc_urls configured", cfg.Name)
+		}
+
+		c := &chain{}
+		for _, url := range cfg.RPCURLs {
+			resolved := resolveRPCURL(url)
+			if resolved == "" {
+				continue
+			}
+			rpcClient, err := rpc.Dial(resolved)
+			if err != nil {
+				return nil, fmt.Errorf("chain %q: dial %q: %w", cfg.Name, resolved, err)
+			}
+			adapter := &ethClientAdapter{Client: ethclient.NewClient(rpcClient), rpcClient: rpcClient}
+			client := NewEthereumClient(newInstrumentedClient(adapter))
+			c.endpoints = append(c.endpoints, &chainEndpoint{url: resolved, client: client})
+		}
+		if len(c.endpoints) == 0 {
+			return nil, fmt.Errorf("chain %q has no usable rpc_urls after resolving environment variables", cfg.Name)
+		}
+		registry.chains[cfg.Name] = c
+	}
+	return registry, nil
+}
+
+func (r *ClientRegistry) chainByName(name string) (*chain, error) {
+	c, ok := r.chains[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown chain %q", name)
+	}
+	return c, nil
+}
+
+// ClientFor returns the primary (first configured) client for a chain, for
+// callers like WebSocket subscriptions that hold a long-lived connection
+// rather than making one-off failover-able calls.
+func (r *ClientRegistry) ClientFor(name string) (*EthereumClient, error) {
+	c, err := r.chainByName(name)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.endpoints[0].client, nil
+}
+
+func (r *ClientRegistry) LatestBlockNumber(name string) (*big.Int, error) {
+	c, err := r.chainByName(name)
+	if err != nil {
+		return nil, err
+	}
+	var blockNumber *big.Int
+	err = c.do(func(ec *EthereumClient) error {
+		n, err := ec.getLatestBlockNumber()
+		if err != nil {
+			return err
+		}
+		blockNumber = n
+		return nil
+	})
+	return blockNumber, err
+}
+
+func (r *ClientRegistry) BalanceAt(name, address string, blockNumber *big.Int) (*big.Int, error) {
+	c, err := r.chainByName(name)
+	if err != nil {
+		return nil, err
+	}
+	var balance *big.Int
+	err = c.do(func(ec *EthereumClient) error {
+		b, err := ec.getBalanceAt(address, blockNumber)
+		if err != nil {
+			return err
+		}
+		balance = b
+		return nil
+	})
+	return balance, err
+}
+
+func (r *ClientRegistry) HeaderByNumber(name string, blockNumber *big.Int) (*types.Header, error) {
+	c, err := r.chainByName(name)
+	if err != nil {
+		return nil, err
+	}
+	var header *types.Header
+	err = c.do(func(ec *EthereumClient) error {
+		h, err := ec.getHeaderByNumber(blockNumber)
+		if err != nil {
+			return err
+		}
+		header = h
+		return nil
+	})
+	return header, err
+}
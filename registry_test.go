@@ -0,0 +1,85 @@
+package main
+
+import (
+	"errors"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestChainDo_FailsOverToNextEndpoint(t *testing.T) {
+	failing := new(MockEthereumClient)
+	failing.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return((*types.Header)(nil), errors.New("connection refused"))
+
+	expectedBlockNumber := big.NewInt(42)
+	healthy := new(MockEthereumClient)
+	healthy.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{Number: expectedBlockNumber}, nil)
+
+	c := &chain{endpoints: []*chainEndpoint{
+		{url: "bad", client: NewEthereumClient(failing)},
+		{url: "good", client: NewEthereumClient(healthy)},
+	}}
+
+	var got *big.Int
+	err := c.do(func(ec *EthereumClient) error {
+		n, err := ec.getLatestBlockNumber()
+		if err != nil {
+			return err
+		}
+		got = n
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedBlockNumber, got)
+	assert.True(t, c.endpoints[0].unhealthyUntil.After(time.Now()))
+	failing.AssertExpectations(t)
+	healthy.AssertExpectations(t)
+}
+
+func TestChainDo_HeaderNotFoundIsNotAFailover(t *testing.T) {
+	mockClient := new(MockEthereumClient)
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return((*types.Header)(nil), ethereum.NotFound)
+
+	c := &chain{endpoints: []*chainEndpoint{{url: "only", client: NewEthereumClient(mockClient)}}}
+
+	err := c.do(func(ec *EthereumClient) error {
+		_, err := ec.getHeaderByNumber(nil)
+		return err
+	})
+
+	assert.ErrorIs(t, err, ethereum.NotFound)
+	assert.False(t, c.endpoints[0].unhealthyUntil.After(time.Now()))
+	mockClient.AssertExpectations(t)
+}
+
+func TestResolveRPCURL(t *testing.T) {
+	t.Setenv("TEST_RPC_URL", "https://example.com/rpc")
+
+	assert.Equal(t, "https://example.com/rpc", resolveRPCURL("${TEST_RPC_URL}"))
+	assert.Equal(t, "https://rpc.sepolia.org", resolveRPCURL("https://rpc.sepolia.org"))
+
+	os.Unsetenv("TEST_RPC_UNSET")
+	assert.Equal(t, "", resolveRPCURL("${TEST_RPC_UNSET}"))
+}
+
+func TestChainDo_AllEndpointsUnhealthy(t *testing.T) {
+	mockClient := new(MockEthereumClient)
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return((*types.Header)(nil), errors.New("timeout"))
+
+	c := &chain{endpoints: []*chainEndpoint{{url: "only", client: NewEthereumClient(mockClient)}}}
+
+	err := c.do(func(ec *EthereumClient) error {
+		_, err := ec.getLatestBlockNumber()
+		return err
+	})
+
+	assert.Error(t, err)
+	mockClient.AssertExpectations(t)
+}
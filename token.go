@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Function selectors are the first 4 bytes of keccak256("<signature>"), e.g.
+// keccak256("balanceOf(address)")[:4]. They're hardcoded here rather than
+// computed because the set of calls this client makes is fixed and small;
+// pulling in the full abi/abigen machinery for three read-only calls would
+// be overkill for this demo.
+const (
+	selectorBalanceOf = "70a08231" // balanceOf(address)
+	selectorDecimals  = "313ce567" // decimals()
+	selectorSymbol    = "95d89b41" // symbol()
+)
+
+func encodeAddressCall(selectorHex string, args ...common.Address) ([]byte, error) {
+	selector, err := hex.DecodeString(selectorHex)
+	if err != nil {
+		return nil, err
+	}
+	data := selector
+	for _, arg := range args {
+		data = append(data, common.LeftPadBytes(arg.Bytes(), 32)...)
+	}
+	return data, nil
+}
+
+// getTokenBalance calls the ERC-20 balanceOf(address) method on token and
+// decodes the returned 32-byte word.
+func (ec *EthereumClient) getTokenBalance(token, holder common.Address) (*big.Int, error) {
+	data, err := encodeAddressCall(selectorBalanceOf, holder)
+	if err != nil {
+		return nil, err
+	}
+	result, err := ec.client.CallContract(context.Background(), ethereum.CallMsg{To: &token, Data: data}, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("balanceOf(%s) on %s returned no data", holder.Hex(), token.Hex())
+	}
+	return new(big.Int).SetBytes(result), nil
+}
+
+// getTokenDecimals calls the (optional, non-standard but near-universal)
+// ERC-20 decimals() method.
+func (ec *EthereumClient) getTokenDecimals(token common.Address) (uint8, error) {
+	data, err := encodeAddressCall(selectorDecimals)
+	if err != nil {
+		return 0, err
+	}
+	result, err := ec.client.CallContract(context.Background(), ethereum.CallMsg{To: &token, Data: data}, nil)
+	if err != nil {
+		return 0, err
+	}
+	if len(result) == 0 {
+		return 0, fmt.Errorf("decimals() on %s returned no data", token.Hex())
+	}
+	return uint8(new(big.Int).SetBytes(result).Uint64()), nil
+}
+
+// getTokenSymbol calls the (optional) ERC-20 symbol() method and decodes the
+// ABI-encoded dynamic string it returns.
+func (ec *EthereumClient) getTokenSymbol(token common.Address) (string, error) {
+	data, err := encodeAddressCall(selectorSymbol)
+	if err != nil {
+		return "", err
+	}
+	result, err := ec.client.CallContract(context.Background(), ethereum.CallMsg{To: &token, Data: data}, nil)
+	if err != nil {
+		return "", err
+	}
+	return decodeABIString(result)
+}
+
+// decodeABIString decodes a Solidity ABI-encoded `string` return value: a
+// 32-byte offset, followed (at that offset) by a 32-byte length and the
+// UTF-8 bytes themselves.
+func decodeABIString(data []byte) (string, error) {
+	if len(data) < 64 {
+		return "", errors.New("malformed ABI string: shorter than the head")
+	}
+	offset := new(big.Int).SetBytes(data[:32]).Uint64()
+	if uint64(len(data)) < offset+32 {
+		return "", errors.New("malformed ABI string: offset out of range")
+	}
+	length := new(big.Int).SetBytes(data[offset : offset+32]).Uint64()
+	start := offset + 32
+	if uint64(len(data)) < start+length {
+		return "", errors.New("malformed ABI string: length out of range")
+	}
+	return string(data[start : start+length]), nil
+}
+
+func tokenBalanceHandler(registry *ClientRegistry, chain string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tokenParam := r.URL.Query().Get("token")
+		addressParam := r.URL.Query().Get("address")
+		if tokenParam == "" || addressParam == "" {
+			http.Error(w, "token and address are required", http.StatusBadRequest)
+			return
+		}
+		token := common.HexToAddress(tokenParam)
+		holder := common.HexToAddress(addressParam)
+
+		balance, err := registry.TokenBalance(chain, token, holder)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		resp := map[string]interface{}{"balance": balance.String()}
+
+		if ethClient, err := registry.ClientFor(chain); err == nil {
+			if decimals, err := ethClient.getTokenDecimals(token); err == nil {
+				resp["decimals"] = decimals
+			}
+			if symbol, err := ethClient.getTokenSymbol(token); err == nil {
+				resp["symbol"] = symbol
+			}
+		}
+
+		json.NewEncoder(w).Encode(resp)
+	}
+}
+
+func (r *ClientRegistry) TokenBalance(name string, token, holder common.Address) (*big.Int, error) {
+	c, err := r.chainByName(name)
+	if err != nil {
+		return nil, err
+	}
+	var balance *big.Int
+	err = c.do(func(ec *EthereumClient) error {
+		b, err := ec.getTokenBalance(token, holder)
+		if err != nil {
+			return err
+		}
+		balance = b
+		return nil
+	})
+	return balance, err
+}
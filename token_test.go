@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func encodeABIString(s string) []byte {
+	data := make([]byte, 32)
+	data[31] = 32 // offset
+	length := make([]byte, 32)
+	new(big.Int).SetUint64(uint64(len(s))).FillBytes(length)
+	data = append(data, length...)
+	data = append(data, common.RightPadBytes([]byte(s), 32)...)
+	return data
+}
+
+func TestGetTokenBalance(t *testing.T) {
+	token := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48") // USDC
+	holder := common.HexToAddress("0x1")
+	expectedBalance := big.NewInt(1_000_000)
+
+	mockClient := new(MockEthereumClient)
+	selector, _ := hex.DecodeString(selectorBalanceOf)
+	data := append(selector, common.LeftPadBytes(holder.Bytes(), 32)...)
+	msg := ethereum.CallMsg{To: &token, Data: data}
+	mockClient.On("CallContract", mock.Anything, msg, (*big.Int)(nil)).
+		Return(common.LeftPadBytes(expectedBalance.Bytes(), 32), nil)
+
+	ethClient := NewEthereumClient(mockClient)
+	balance, err := ethClient.getTokenBalance(token, holder)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedBalance, balance)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGetTokenDecimals(t *testing.T) {
+	token := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+
+	mockClient := new(MockEthereumClient)
+	selector, _ := hex.DecodeString(selectorDecimals)
+	msg := ethereum.CallMsg{To: &token, Data: selector}
+	mockClient.On("CallContract", mock.Anything, msg, (*big.Int)(nil)).
+		Return(common.LeftPadBytes([]byte{6}, 32), nil)
+
+	ethClient := NewEthereumClient(mockClient)
+	decimals, err := ethClient.getTokenDecimals(token)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(6), decimals)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGetTokenSymbol(t *testing.T) {
+	token := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+
+	mockClient := new(MockEthereumClient)
+	selector, _ := hex.DecodeString(selectorSymbol)
+	msg := ethereum.CallMsg{To: &token, Data: selector}
+	mockClient.On("CallContract", mock.Anything, msg, (*big.Int)(nil)).
+		Return(encodeABIString("USDC"), nil)
+
+	ethClient := NewEthereumClient(mockClient)
+	symbol, err := ethClient.getTokenSymbol(token)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "USDC", symbol)
+	mockClient.AssertExpectations(t)
+}
+
+func TestDecodeABIString_Malformed(t *testing.T) {
+	_, err := decodeABIString([]byte{0x01, 0x02})
+	assert.Error(t, err)
+}
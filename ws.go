@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/gorilla/websocket"
+)
+
+// wsUpgrader upgrades plain HTTP connections to WebSocket connections for the
+// push-update endpoints below. Origin checking is left permissive since this
+// is a demo dashboard backend, not a browser-facing production service.
+var wsUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin:     func(r *http.Request) bool { return true },
+}
+
+// wsReconnectBackoff is how long we wait before re-subscribing after Infura
+// drops a subscription (e.g. on an idle timeout or node restart).
+const wsReconnectBackoff = 2 * time.Second
+
+// newHeadsWSHandler streams newly mined block headers as JSON over a
+// WebSocket connection, transparently resubscribing if the upstream
+// subscription errors out.
+func newHeadsWSHandler(ec *EthereumClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			log.Printf("new-heads: upgrade failed: %v", err)
+			return
+		}
+		defer conn.Close()
+
+		ctx := r.Context()
+		for {
+			err := streamNewHeads(ctx, ec, conn)
+			if ctx.Err() != nil {
+				return
+			}
+			if err == nil {
+				return
+			}
+			log.Printf("new-heads: subscription dropped, reconnecting in %s: %v", wsReconnectBackoff, err)
+			select {
+			case <-time.After(wsReconnectBackoff):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+func streamNewHeads(ctx context.Context, ec *EthereumClient, conn *websocket.Conn) error {
+	headers := make(chan *types.Header)
+	sub, err := ec.subscribeNewHeads(ctx, headers)
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case err := <-sub.Err():
+			return err
+		case header := <-headers:
+			if err := conn.WriteJSON(header); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// pendingTxsWSHandler streams pending transaction hashes as JSON over a
+// WebSocket connection, with the same reconnect/backoff behavior as
+// newHeadsWSHandler.
+func pendingTxsWSHandler(ec *EthereumClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			log.Printf("pending-txs: upgrade failed: %v", err)
+			return
+		}
+		defer conn.Close()
+
+		ctx := r.Context()
+		for {
+			err := streamPendingTransactions(ctx, ec, conn)
+			if ctx.Err() != nil {
+				return
+			}
+			if err == nil {
+				return
+			}
+			log.Printf("pending-txs: subscription dropped, reconnecting in %s: %v", wsReconnectBackoff, err)
+			select {
+			case <-time.After(wsReconnectBackoff):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+func streamPendingTransactions(ctx context.Context, ec *EthereumClient, conn *websocket.Conn) error {
+	txHashes := make(chan common.Hash)
+	sub, err := ec.subscribePendingTransactions(ctx, txHashes)
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case err := <-sub.Err():
+			return err
+		case hash := <-txHashes:
+			if err := conn.WriteJSON(map[string]string{"hash": hash.Hex()}); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// logsWSHandler tails logs matching query as they're mined, streaming each
+// as a JSON text frame, with the same reconnect/backoff behavior as the
+// other subscription handlers.
+func logsWSHandler(ec *EthereumClient, query ethereum.FilterQuery) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			log.Printf("logs: upgrade failed: %v", err)
+			return
+		}
+		defer conn.Close()
+
+		ctx := r.Context()
+		for {
+			err := streamFilterLogs(ctx, ec, query, conn)
+			if ctx.Err() != nil {
+				return
+			}
+			if err == nil {
+				return
+			}
+			log.Printf("logs: subscription dropped, reconnecting in %s: %v", wsReconnectBackoff, err)
+			select {
+			case <-time.After(wsReconnectBackoff):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+func streamFilterLogs(ctx context.Context, ec *EthereumClient, query ethereum.FilterQuery, conn *websocket.Conn) error {
+	logsCh := make(chan types.Log)
+	sub, err := ec.subscribeFilterLogs(ctx, query, logsCh)
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case err := <-sub.Err():
+			return err
+		case entry := <-logsCh:
+			payload, err := logJSON(entry)
+			if err != nil {
+				continue
+			}
+			if err := conn.WriteMessage(websocket.TextMessage, payload); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// fakeSubscription is a minimal ethereum.Subscription whose Err channel can
+// be fed on demand, for exercising the reconnect/backoff loop in ws.go
+// without a live RPC connection.
+type fakeSubscription struct {
+	errCh chan error
+}
+
+func newFakeSubscription() *fakeSubscription {
+	return &fakeSubscription{errCh: make(chan error, 1)}
+}
+
+func (s *fakeSubscription) Unsubscribe()      {}
+func (s *fakeSubscription) Err() <-chan error { return s.errCh }
+
+func TestStreamNewHeads_PropagatesSubscriptionError(t *testing.T) {
+	mockClient := new(MockEthereumClient)
+	sub := newFakeSubscription()
+	wantErr := errors.New("subscription dropped")
+	sub.errCh <- wantErr
+	mockClient.On("SubscribeNewHead", mock.Anything, mock.Anything).Return(sub, nil).Once()
+
+	ec := NewEthereumClient(mockClient)
+	err := streamNewHeads(context.Background(), ec, nil)
+
+	assert.ErrorIs(t, err, wantErr)
+	mockClient.AssertExpectations(t)
+}
+
+func TestStreamNewHeads_ExitsCleanlyOnContextDone(t *testing.T) {
+	mockClient := new(MockEthereumClient)
+	sub := newFakeSubscription()
+	mockClient.On("SubscribeNewHead", mock.Anything, mock.Anything).Return(sub, nil).Once()
+
+	ec := NewEthereumClient(mockClient)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := streamNewHeads(ctx, ec, nil)
+
+	assert.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestStreamPendingTransactions_PropagatesSubscriptionError(t *testing.T) {
+	mockClient := new(MockEthereumClient)
+	sub := newFakeSubscription()
+	wantErr := errors.New("subscription dropped")
+	sub.errCh <- wantErr
+	mockClient.On("SubscribePendingTransactions", mock.Anything, mock.Anything).Return(sub, nil).Once()
+
+	ec := NewEthereumClient(mockClient)
+	err := streamPendingTransactions(context.Background(), ec, nil)
+
+	assert.ErrorIs(t, err, wantErr)
+	mockClient.AssertExpectations(t)
+}
+
+// TestNewHeadsWSHandler_ResubscribesAfterSubscriptionError drives the full
+// newHeadsWSHandler reconnect loop (not just streamNewHeads in isolation)
+// against a subscription that errors once then succeeds, and checks that
+// closing the client connection stops the loop without a further
+// resubscribe attempt.
+func TestNewHeadsWSHandler_ResubscribesAfterSubscriptionError(t *testing.T) {
+	mockClient := new(MockEthereumClient)
+
+	failing := newFakeSubscription()
+	failing.errCh <- errors.New("subscription dropped")
+	healthy := newFakeSubscription()
+
+	resubscribed := make(chan struct{})
+	mockClient.On("SubscribeNewHead", mock.Anything, mock.Anything).Return(failing, nil).Once()
+	mockClient.On("SubscribeNewHead", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		close(resubscribed)
+	}).Return(healthy, nil).Once()
+
+	ec := NewEthereumClient(mockClient)
+	server := httptest.NewServer(newHeadsWSHandler(ec))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	assert.NoError(t, err)
+
+	select {
+	case <-resubscribed:
+	case <-time.After(wsReconnectBackoff + 2*time.Second):
+		t.Fatal("handler did not resubscribe after the first subscription error")
+	}
+
+	conn.Close()
+
+	// Give the handler goroutine a moment to observe the closed connection
+	// and exit via ctx.Done(); if it looped back to SubscribeNewHead again
+	// instead, the exhausted .Once() expectations above would fail here.
+	time.Sleep(100 * time.Millisecond)
+	mockClient.AssertExpectations(t)
+}